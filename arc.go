@@ -0,0 +1,225 @@
+package lru
+
+import (
+	"sync"
+
+	"github.com/sentioxyz/golang-lru/simplelru"
+)
+
+// ARCCache is a thread-safe fixed size Adaptive Replacement Cache (ARC).
+// ARC adapts between recency (like Cache) and frequency, which makes it
+// a drop-in for workloads that suffer from LRU's scan sensitivity.
+type ARCCache[K comparable, V any] struct {
+	arc         *simplelru.ARC[K, V]
+	evictedKeys []K
+	evictedVals []V
+	onEvictedCB func(k K, v V)
+	lock        sync.RWMutex
+
+	// evictMu guards evictedKeys/evictedVals independently of lock, same
+	// reason as Cache.evictMu.
+	evictMu sync.Mutex
+}
+
+// NewARC creates an ARC of the given size.
+func NewARC[K comparable, V any](size int) (*ARCCache[K, V], error) {
+	return NewARCWithEvict[K, V](size, nil)
+}
+
+// NewARCWithEvict constructs a fixed size ARC with the given eviction
+// callback.
+func NewARCWithEvict[K comparable, V any](size int, onEvicted func(key K, value V)) (c *ARCCache[K, V], err error) {
+	return NewARCWithWeightLimitAndEvict(size, 0, nil, onEvicted)
+}
+
+// NewARCWithWeightLimitAndEvict constructs a fixed size ARC with the
+// weight limit and given eviction callback.
+func NewARCWithWeightLimitAndEvict[K comparable, V any](
+	size int,
+	weightLimit uint64,
+	weightCalculator func(value V) uint64,
+	onEvicted func(key K, value V),
+) (c *ARCCache[K, V], err error) {
+	c = &ARCCache[K, V]{
+		onEvictedCB: onEvicted,
+	}
+	if onEvicted != nil {
+		c.initEvictBuffers()
+		onEvicted = c.onEvicted
+	}
+	c.arc, err = simplelru.NewARCWithWeightLimit(size, weightLimit, weightCalculator, onEvicted)
+	return
+}
+
+func (c *ARCCache[K, V]) initEvictBuffers() {
+	c.evictedKeys = make([]K, 0, DefaultEvictedBufferSize)
+	c.evictedVals = make([]V, 0, DefaultEvictedBufferSize)
+}
+
+// onEvicted save evicted key/val and sent in externally registered callback
+// outside of critical section
+func (c *ARCCache[K, V]) onEvicted(k K, v V) {
+	c.evictMu.Lock()
+	c.evictedKeys = append(c.evictedKeys, k)
+	c.evictedVals = append(c.evictedVals, v)
+	c.evictMu.Unlock()
+}
+
+func (c *ARCCache[K, V]) collectEvicted(purge bool) (evictedKeys []K, evictedVals []V) {
+	c.evictMu.Lock()
+	defer c.evictMu.Unlock()
+	count := len(c.evictedKeys)
+	if count == 0 {
+		return
+	}
+	if purge {
+		evictedKeys, evictedVals = c.evictedKeys, c.evictedVals
+		c.initEvictBuffers()
+	} else {
+		evictedKeys, evictedVals = make([]K, count), make([]V, count)
+		copy(evictedKeys, c.evictedKeys)
+		copy(evictedVals, c.evictedVals)
+		c.evictedKeys, c.evictedVals = c.evictedKeys[:0], c.evictedVals[:0]
+	}
+	return
+}
+
+func (c *ARCCache[K, V]) callEvictCB(evictedKeys []K, evictedVals []V) {
+	for i := 0; i < len(evictedKeys); i++ {
+		c.onEvictedCB(evictedKeys[i], evictedVals[i])
+	}
+}
+
+// Purge is used to completely clear the cache.
+func (c *ARCCache[K, V]) Purge() {
+	c.lock.Lock()
+	c.arc.Purge()
+	if c.onEvictedCB != nil {
+		ks, vs := c.collectEvicted(true)
+		defer c.callEvictCB(ks, vs)
+	}
+	c.lock.Unlock()
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *ARCCache[K, V]) Add(key K, value V) (evicted bool) {
+	c.lock.Lock()
+	evicted = c.arc.Add(key, value)
+	if c.onEvictedCB != nil && evicted {
+		ks, vs := c.collectEvicted(false)
+		// invoke callback outside of critical section
+		defer c.callEvictCB(ks, vs)
+	}
+	c.lock.Unlock()
+	return
+}
+
+// Get looks up a key's value from the cache.
+func (c *ARCCache[K, V]) Get(key K) (value V, ok bool) {
+	c.lock.Lock()
+	value, ok = c.arc.Get(key)
+	c.lock.Unlock()
+	return value, ok
+}
+
+// Contains checks if a key is in the cache, without updating the
+// recent-ness or frequency of the key.
+func (c *ARCCache[K, V]) Contains(key K) bool {
+	c.lock.RLock()
+	containKey := c.arc.Contains(key)
+	c.lock.RUnlock()
+	return containKey
+}
+
+// Peek returns the key value (or undefined if not found) without updating
+// the "recently used"-ness of the key.
+func (c *ARCCache[K, V]) Peek(key K) (value V, ok bool) {
+	c.lock.RLock()
+	value, ok = c.arc.Peek(key)
+	c.lock.RUnlock()
+	return value, ok
+}
+
+// Remove removes the provided key from the cache.
+func (c *ARCCache[K, V]) Remove(key K) (present bool) {
+	c.lock.Lock()
+	present = c.arc.Remove(key)
+	if c.onEvictedCB != nil && present {
+		ks, vs := c.collectEvicted(false)
+		// invoke callback outside of critical section
+		defer c.callEvictCB(ks, vs)
+	}
+	c.lock.Unlock()
+	return
+}
+
+// Resize changes the cache size.
+func (c *ARCCache[K, V]) Resize(size int) (evicted int) {
+	c.lock.Lock()
+	evicted = c.arc.Resize(size)
+	if c.onEvictedCB != nil && evicted > 0 {
+		ks, vs := c.collectEvicted(true)
+		// invoke callback outside of critical section
+		defer c.callEvictCB(ks, vs)
+	}
+	c.lock.Unlock()
+	return evicted
+}
+
+// ResetWeightLimit changes the weight limit.
+func (c *ARCCache[K, V]) ResetWeightLimit(weightLimit uint64) (evicted int) {
+	c.lock.Lock()
+	evicted = c.arc.ResetWeightLimit(weightLimit)
+	if c.onEvictedCB != nil && evicted > 0 {
+		ks, vs := c.collectEvicted(true)
+		// invoke callback outside of critical section
+		defer c.callEvictCB(ks, vs)
+	}
+	c.lock.Unlock()
+	return evicted
+}
+
+// RemoveOldest removes the oldest item from the cache.
+func (c *ARCCache[K, V]) RemoveOldest() (key K, value V, ok bool) {
+	c.lock.Lock()
+	key, value, ok = c.arc.RemoveOldest()
+	if c.onEvictedCB != nil && ok {
+		ks, vs := c.collectEvicted(true)
+		// invoke callback outside of critical section
+		defer c.callEvictCB(ks, vs)
+	}
+	c.lock.Unlock()
+	return
+}
+
+// GetOldest returns the resident entry RemoveOldest would remove.
+func (c *ARCCache[K, V]) GetOldest() (key K, value V, ok bool) {
+	c.lock.RLock()
+	key, value, ok = c.arc.GetOldest()
+	c.lock.RUnlock()
+	return
+}
+
+// Keys returns a slice of the resident keys in the cache, T1 followed by T2.
+func (c *ARCCache[K, V]) Keys() []K {
+	c.lock.RLock()
+	keys := c.arc.Keys()
+	c.lock.RUnlock()
+	return keys
+}
+
+// Len returns the number of items in the cache.
+func (c *ARCCache[K, V]) Len() int {
+	c.lock.RLock()
+	length := c.arc.Len()
+	c.lock.RUnlock()
+	return length
+}
+
+// WeightTotal returns the sum of the weight of all the entries in the cache.
+func (c *ARCCache[K, V]) WeightTotal() uint64 {
+	c.lock.RLock()
+	weightTotal := c.arc.WeightTotal()
+	c.lock.RUnlock()
+	return weightTotal
+}
@@ -1,7 +1,9 @@
 package lru
 
 import (
+	"context"
 	"sync"
+	"time"
 
 	"github.com/sentioxyz/golang-lru/simplelru"
 )
@@ -11,13 +13,57 @@ const (
 	DefaultEvictedBufferSize = 16
 )
 
+// EvictCallbackCtx is like the plain eviction callback accepted by
+// NewWithEvict, except it also receives the context of the mutating
+// call that caused the eviction (AddCtx, GetCtx, PurgeCtx), so that
+// tracing/logging/metrics span propagation survives eviction.
+type EvictCallbackCtx[K comparable, V any] func(ctx context.Context, key K, value V)
+
+// adaptEvictCallbackCtx bridges a plain, context-less eviction callback
+// to EvictCallbackCtx so the non-ctx constructors can keep working
+// unchanged.
+func adaptEvictCallbackCtx[K comparable, V any](onEvicted func(key K, value V)) EvictCallbackCtx[K, V] {
+	if onEvicted == nil {
+		return nil
+	}
+	return func(_ context.Context, key K, value V) {
+		onEvicted(key, value)
+	}
+}
+
+// evictedEntry is a buffered eviction awaiting delivery to onEvictedCB
+// outside of the critical section, tagged with the context of whichever
+// mutating call produced it.
+type evictedEntry[K comparable, V any] struct {
+	ctx   context.Context
+	key   K
+	value V
+}
+
 // Cache is a thread-safe fixed size LRU cache.
 type Cache[K comparable, V any] struct {
 	lru         *simplelru.LRU[K, V]
-	evictedKeys []K
-	evictedVals []V
-	onEvictedCB func(k K, v V)
+	evicted     []evictedEntry[K, V]
+	onEvictedCB EvictCallbackCtx[K, V]
 	lock        sync.RWMutex
+
+	// evictMu guards evicted independently of lock, so callEvictCB can be
+	// deferred until after lock is released without letting a concurrent
+	// call race on appending to evicted in the meantime.
+	evictMu sync.Mutex
+
+	// currentCtx is the context of the in-flight mutating call, consulted
+	// by onEvicted while appending to evicted. Safe without evictMu
+	// because lock is always held exclusively whenever it's written, and
+	// every path that reads it (checkEvict/expiry removals triggered
+	// synchronously by that same call) does so before lock is released.
+	// It is reset to context.Background() once that call is done. Purge,
+	// PurgeMatching and PurgeCtx never touch it: their evictions are
+	// delivered directly by dispatchBackground/PurgeCtx instead, since a
+	// detached background goroutine has no call of its own to attribute
+	// evictions to and reading this field from one would race with
+	// whatever call happens to be in flight when it wakes up.
+	currentCtx context.Context
 }
 
 // New creates an LRU of the given size.
@@ -39,100 +85,259 @@ func NewWithWeightLimitAndEvict[K comparable, V any](
 	onEvicted func(key K, value V),
 ) (c *Cache[K, V], err error) {
 	// create a cache with default settings
+	c = &Cache[K, V]{
+		onEvictedCB: adaptEvictCallbackCtx[K, V](onEvicted),
+		currentCtx:  context.Background(),
+	}
+	var innerEvict func(key K, value V)
+	if onEvicted != nil {
+		c.initEvictBuffers()
+		innerEvict = c.onEvicted
+	}
+	c.lru, err = simplelru.NewLRUWithWeightLimit(size, weightLimit, weightCalculator, innerEvict)
+	return
+}
+
+// NewWithTTL constructs a fixed size cache whose entries expire ttl
+// after being added, unless overridden per entry via AddWithTTL. A
+// non-positive ttl means entries never expire on their own.
+func NewWithTTL[K comparable, V any](size int, ttl time.Duration, onEvicted func(key K, value V)) (c *Cache[K, V], err error) {
+	c = &Cache[K, V]{
+		onEvictedCB: adaptEvictCallbackCtx[K, V](onEvicted),
+		currentCtx:  context.Background(),
+	}
+	var innerEvict func(key K, value V)
+	if onEvicted != nil {
+		c.initEvictBuffers()
+		innerEvict = c.onEvicted
+	}
+	c.lru, err = simplelru.NewLRUWithTTL(size, ttl, innerEvict)
+	return
+}
+
+// NewWithEvictCtx constructs a fixed size cache whose eviction callback
+// receives the context of the mutating call (AddCtx, GetCtx, PurgeCtx)
+// that caused the eviction. Calls made through the non-ctx API
+// (Add, Get, Purge, ...) supply context.Background().
+func NewWithEvictCtx[K comparable, V any](size int, onEvicted EvictCallbackCtx[K, V]) (c *Cache[K, V], err error) {
 	c = &Cache[K, V]{
 		onEvictedCB: onEvicted,
+		currentCtx:  context.Background(),
 	}
+	var innerEvict func(key K, value V)
 	if onEvicted != nil {
 		c.initEvictBuffers()
-		onEvicted = c.onEvicted
+		innerEvict = c.onEvicted
 	}
-	c.lru, err = simplelru.NewLRUWithWeightLimit(size, weightLimit, weightCalculator, onEvicted)
+	c.lru, err = simplelru.NewLRUWithWeightLimit(size, 0, nil, innerEvict)
 	return
 }
 
 func (c *Cache[K, V]) initEvictBuffers() {
-	c.evictedKeys = make([]K, 0, DefaultEvictedBufferSize)
-	c.evictedVals = make([]V, 0, DefaultEvictedBufferSize)
+	c.evicted = make([]evictedEntry[K, V], 0, DefaultEvictedBufferSize)
 }
 
 // onEvicted save evicted key/val and sent in externally registered callback
 // outside of critical section
 func (c *Cache[K, V]) onEvicted(k K, v V) {
-	c.evictedKeys = append(c.evictedKeys, k)
-	c.evictedVals = append(c.evictedVals, v)
+	c.evictMu.Lock()
+	c.evicted = append(c.evicted, evictedEntry[K, V]{ctx: c.currentCtx, key: k, value: v})
+	c.evictMu.Unlock()
 }
 
-func (c *Cache[K, V]) collectEvicted(purge bool) (evictedKeys []K, evictedVals []V) {
-	count := len(c.evictedKeys)
+func (c *Cache[K, V]) collectEvicted(purge bool) (evicted []evictedEntry[K, V]) {
+	c.evictMu.Lock()
+	defer c.evictMu.Unlock()
+	count := len(c.evicted)
 	if count == 0 {
 		return
 	}
 	if purge {
-		evictedKeys, evictedVals = c.evictedKeys, c.evictedVals
+		evicted = c.evicted
 		c.initEvictBuffers()
 	} else {
-		evictedKeys, evictedVals = make([]K, count), make([]V, count)
-		copy(evictedKeys, c.evictedKeys)
-		copy(evictedVals, c.evictedVals)
-		c.evictedKeys, c.evictedVals = c.evictedKeys[:0], c.evictedVals[:0]
+		evicted = make([]evictedEntry[K, V], count)
+		copy(evicted, c.evicted)
+		c.evicted = c.evicted[:0]
 	}
 	return
 }
 
-func (c *Cache[K, V]) callEvictCB(evictedKeys []K, evictedVals []V) {
-	for i := 0; i < len(evictedKeys); i++ {
-		c.onEvictedCB(evictedKeys[i], evictedVals[i])
+func (c *Cache[K, V]) callEvictCB(evicted []evictedEntry[K, V]) {
+	for i := range evicted {
+		c.onEvictedCB(evicted[i].ctx, evicted[i].key, evicted[i].value)
 	}
 }
 
-// Purge is used to completely clear the cache.
+// Purge is used to completely clear the cache. The live index is swapped
+// out under the lock, and the discarded entries are copied into a map
+// before the lock is released, so Purge is not O(1): the time the lock is
+// held grows with the number of entries. The copied entries are then
+// handed to dispatchBackground, which fires the eviction callback from a
+// background goroutine rather than under the lock.
 func (c *Cache[K, V]) Purge() {
 	c.lock.Lock()
-	c.lru.Purge()
-	if c.onEvictedCB != nil && len(c.evictedKeys) > 0 {
-		ks, vs := c.collectEvicted(true)
-		// invoke callback outside of critical section
-		defer c.callEvictCB(ks, vs)
+	discarded := c.lru.PurgeCollecting()
+	c.lock.Unlock()
+	c.dispatchBackground(discarded)
+}
+
+// PurgeCtx is like Purge, but passes ctx to the eviction callback for
+// every purged entry, firing synchronously right after the lock is
+// released rather than from a background goroutine, since a detached
+// goroutine would have no way to attribute its entries to ctx.
+func (c *Cache[K, V]) PurgeCtx(ctx context.Context) {
+	c.lock.Lock()
+	discarded := c.lru.PurgeCollecting()
+	c.lock.Unlock()
+	if c.onEvictedCB == nil {
+		return
+	}
+	for k, v := range discarded {
+		c.onEvictedCB(ctx, k, v)
 	}
+}
+
+// PurgeMatching removes every entry for which matches returns true.
+// Like Purge, any eviction callback fires from a background goroutine
+// rather than synchronously under the lock.
+func (c *Cache[K, V]) PurgeMatching(matches func(key K, value V) bool) {
+	c.lock.Lock()
+	discarded := c.lru.PurgeMatchingCollecting(matches)
 	c.lock.Unlock()
+	c.dispatchBackground(discarded)
+}
+
+// dispatchBackground fires onEvictedCB for every discarded entry from a
+// background goroutine, with context.Background() - the same ctx the
+// non-ctx API always supplies - since a Purge/PurgeMatching background
+// goroutine has no call of its own to attribute entries to. It never
+// touches currentCtx or the evicted buffer, so it can't race with a
+// concurrent AddCtx/GetCtx the way reading currentCtx from here used to.
+func (c *Cache[K, V]) dispatchBackground(discarded map[K]V) {
+	if c.onEvictedCB == nil || len(discarded) == 0 {
+		return
+	}
+	go func() {
+		for k, v := range discarded {
+			c.onEvictedCB(context.Background(), k, v)
+		}
+	}()
 }
 
 // Add adds a value to the cache. Returns true if an eviction occurred.
 func (c *Cache[K, V]) Add(key K, value V) (evicted bool) {
+	return c.AddCtx(context.Background(), key, value)
+}
+
+// AddCtx is like Add, but ctx is attached to the eviction this call
+// causes (if any) and passed to the eviction callback registered via
+// NewWithEvictCtx.
+func (c *Cache[K, V]) AddCtx(ctx context.Context, key K, value V) (evicted bool) {
 	c.lock.Lock()
+	c.currentCtx = ctx
 	evicted = c.lru.Add(key, value)
-	if c.onEvictedCB != nil && evicted {
-		ks, vs := c.collectEvicted(false)
-		// invoke callback outside of critical section
-		defer c.callEvictCB(ks, vs)
+	c.currentCtx = context.Background()
+	// Checked regardless of evicted: overwriting an existing key fires
+	// ReasonReplaced without bumping evicted, since the key never left
+	// the cache.
+	if c.onEvictedCB != nil {
+		if entries := c.collectEvicted(false); len(entries) > 0 {
+			// invoke callback outside of critical section
+			defer c.callEvictCB(entries)
+		}
 	}
 	c.lock.Unlock()
 	return
 }
 
+// AddWithTTL adds a value to the cache with a per-entry TTL override,
+// overriding the cache's default TTL (if any). Returns true if an
+// eviction occurred.
+func (c *Cache[K, V]) AddWithTTL(key K, value V, ttl time.Duration) (evicted bool) {
+	c.lock.Lock()
+	evicted = c.lru.AddWithTTL(key, value, ttl)
+	// Checked regardless of evicted: overwriting an existing key fires
+	// ReasonReplaced without bumping evicted, since the key never left
+	// the cache.
+	if c.onEvictedCB != nil {
+		if entries := c.collectEvicted(false); len(entries) > 0 {
+			// invoke callback outside of critical section
+			defer c.callEvictCB(entries)
+		}
+	}
+	c.lock.Unlock()
+	return
+}
+
+// DeleteExpired eagerly reclaims every currently expired entry,
+// returning the number removed, for callers that want to reclaim
+// memory ahead of the next access-triggered expiration check.
+func (c *Cache[K, V]) DeleteExpired() (removed int) {
+	c.lock.Lock()
+	removed = c.lru.DeleteExpired()
+	if c.onEvictedCB != nil && removed > 0 {
+		entries := c.collectEvicted(true)
+		// invoke callback outside of critical section
+		defer c.callEvictCB(entries)
+	}
+	c.lock.Unlock()
+	return removed
+}
+
 // Get looks up a key's value from the cache.
 func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	return c.GetCtx(context.Background(), key)
+}
+
+// GetCtx is like Get, but ctx is attached to the eviction lazy TTL
+// expiry causes (if any) and passed to the eviction callback registered
+// via NewWithEvictCtx.
+func (c *Cache[K, V]) GetCtx(ctx context.Context, key K) (value V, ok bool) {
 	c.lock.Lock()
+	c.currentCtx = ctx
 	value, ok = c.lru.Get(key)
+	c.currentCtx = context.Background()
+	if c.onEvictedCB != nil {
+		if entries := c.collectEvicted(false); len(entries) > 0 {
+			// invoke callback outside of critical section
+			defer c.callEvictCB(entries)
+		}
+	}
 	c.lock.Unlock()
 	return value, ok
 }
 
 // Contains checks if a key is in the cache, without updating the
-// recent-ness or deleting it for being stale.
+// recent-ness. It still takes the write lock: a TTL-capable cache's
+// Contains lazily removes an expired entry and reports it absent, which
+// mutates the same state Add/Get do.
 func (c *Cache[K, V]) Contains(key K) bool {
-	c.lock.RLock()
+	c.lock.Lock()
 	containKey := c.lru.Contains(key)
-	c.lock.RUnlock()
+	if c.onEvictedCB != nil {
+		if entries := c.collectEvicted(false); len(entries) > 0 {
+			// invoke callback outside of critical section
+			defer c.callEvictCB(entries)
+		}
+	}
+	c.lock.Unlock()
 	return containKey
 }
 
 // Peek returns the key value (or undefined if not found) without updating
-// the "recently used"-ness of the key.
+// the "recently used"-ness of the key. It still takes the write lock, for
+// the same lazy-expiry reason as Contains.
 func (c *Cache[K, V]) Peek(key K) (value V, ok bool) {
-	c.lock.RLock()
+	c.lock.Lock()
 	value, ok = c.lru.Peek(key)
-	c.lock.RUnlock()
+	if c.onEvictedCB != nil {
+		if entries := c.collectEvicted(false); len(entries) > 0 {
+			// invoke callback outside of critical section
+			defer c.callEvictCB(entries)
+		}
+	}
+	c.lock.Unlock()
 	return value, ok
 }
 
@@ -147,9 +352,9 @@ func (c *Cache[K, V]) GetOrAdd(key K, value V) (previous V, ok, evicted bool) {
 	}
 	evicted = c.lru.Add(key, value)
 	if c.onEvictedCB != nil && evicted {
-		ks, vs := c.collectEvicted(false)
+		entries := c.collectEvicted(false)
 		// invoke callback outside of critical section
-		defer c.callEvictCB(ks, vs)
+		defer c.callEvictCB(entries)
 	}
 	c.lock.Unlock()
 	return previous, ok, evicted
@@ -166,9 +371,9 @@ func (c *Cache[K, V]) ContainsOrAdd(key K, value V) (ok, evicted bool) {
 	}
 	evicted = c.lru.Add(key, value)
 	if c.onEvictedCB != nil && evicted {
-		ks, vs := c.collectEvicted(false)
+		entries := c.collectEvicted(false)
 		// invoke callback outside of critical section
-		defer c.callEvictCB(ks, vs)
+		defer c.callEvictCB(entries)
 	}
 	c.lock.Unlock()
 	return false, evicted
@@ -186,9 +391,9 @@ func (c *Cache[K, V]) PeekOrAdd(key K, value V) (previous V, ok, evicted bool) {
 	}
 	evicted = c.lru.Add(key, value)
 	if c.onEvictedCB != nil && evicted {
-		ks, vs := c.collectEvicted(false)
+		entries := c.collectEvicted(false)
 		// invoke callback outside of critical section
-		defer c.callEvictCB(ks, vs)
+		defer c.callEvictCB(entries)
 	}
 	c.lock.Unlock()
 	return
@@ -199,9 +404,9 @@ func (c *Cache[K, V]) Remove(key K) (present bool) {
 	c.lock.Lock()
 	present = c.lru.Remove(key)
 	if c.onEvictedCB != nil && present {
-		ks, vs := c.collectEvicted(false)
+		entries := c.collectEvicted(false)
 		// invoke callback outside of critical section
-		defer c.callEvictCB(ks, vs)
+		defer c.callEvictCB(entries)
 	}
 	c.lock.Unlock()
 	return
@@ -212,9 +417,9 @@ func (c *Cache[K, V]) Resize(size int) (evicted int) {
 	c.lock.Lock()
 	evicted = c.lru.Resize(size)
 	if c.onEvictedCB != nil && evicted > 0 {
-		ks, vs := c.collectEvicted(true)
+		entries := c.collectEvicted(true)
 		// invoke callback outside of critical section
-		defer c.callEvictCB(ks, vs)
+		defer c.callEvictCB(entries)
 	}
 	c.lock.Unlock()
 	return evicted
@@ -225,9 +430,9 @@ func (c *Cache[K, V]) ResetWeightLimit(weightLimit uint64) (evicted int) {
 	c.lock.Lock()
 	evicted = c.lru.ResetWeightLimit(weightLimit)
 	if c.onEvictedCB != nil && evicted > 0 {
-		ks, vs := c.collectEvicted(true)
+		entries := c.collectEvicted(true)
 		// invoke callback outside of critical section
-		defer c.callEvictCB(ks, vs)
+		defer c.callEvictCB(entries)
 	}
 	c.lock.Unlock()
 	return evicted
@@ -238,9 +443,9 @@ func (c *Cache[K, V]) RemoveOldest() (key K, value V, ok bool) {
 	c.lock.Lock()
 	key, value, ok = c.lru.RemoveOldest()
 	if c.onEvictedCB != nil && ok {
-		ks, vs := c.collectEvicted(true)
+		entries := c.collectEvicted(true)
 		// invoke callback outside of critical section
-		defer c.callEvictCB(ks, vs)
+		defer c.callEvictCB(entries)
 	}
 	c.lock.Unlock()
 	return
@@ -0,0 +1,68 @@
+package lru
+
+import (
+	"context"
+	"testing"
+)
+
+type ctxKey struct{}
+
+// TestCacheAddCtxPropagatesContext checks that AddCtx attaches its ctx to
+// the eviction it causes, and that a plain Add (no ctx) delivers
+// context.Background() instead.
+func TestCacheAddCtxPropagatesContext(t *testing.T) {
+	var gotCtx context.Context
+	c, err := NewWithEvictCtx[int, int](1, func(ctx context.Context, k, v int) {
+		gotCtx = ctx
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Add(1, 1) // fills the cache; no eviction yet
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "evict-me")
+	c.AddCtx(ctx, 2, 2) // evicts key 1, attributed to ctx
+
+	if gotCtx == nil || gotCtx.Value(ctxKey{}) != "evict-me" {
+		t.Fatalf("want eviction ctx to carry the AddCtx context, got %v", gotCtx)
+	}
+
+	c.Add(3, 3) // evicts key 2, via plain Add
+	if gotCtx.Value(ctxKey{}) != nil {
+		t.Fatalf("want plain Add's eviction to use context.Background(), got %v", gotCtx)
+	}
+}
+
+// TestCachePurgeCtxDeliversContextSynchronously checks that PurgeCtx
+// attributes every discarded entry to the given ctx, and that the
+// callback has already run by the time PurgeCtx returns (unlike Purge,
+// which delivers from a background goroutine).
+func TestCachePurgeCtxDeliversContextSynchronously(t *testing.T) {
+	var calls int
+	var lastCtx context.Context
+	c, err := NewWithEvictCtx[int, int](4, func(ctx context.Context, k, v int) {
+		calls++
+		lastCtx = ctx
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		c.Add(i, i)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "purge")
+	c.PurgeCtx(ctx)
+
+	if calls != 3 {
+		t.Fatalf("want 3 eviction callbacks, got %d", calls)
+	}
+	if lastCtx.Value(ctxKey{}) != "purge" {
+		t.Fatalf("want purged entries attributed to ctx, got %v", lastCtx)
+	}
+	if c.Len() != 0 {
+		t.Fatalf("want cache empty after PurgeCtx, got %d entries", c.Len())
+	}
+}
@@ -0,0 +1,36 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestCacheContainsPeekConcurrentUnderTTL exercises Contains/Peek from
+// many goroutines against a cache full of already-expired entries. Both
+// methods can lazily reclaim an expired entry they encounter, so this is
+// primarily a `go test -race` regression test for that lazy reclamation
+// racing across goroutines sharing only a read lock.
+func TestCacheContainsPeekConcurrentUnderTTL(t *testing.T) {
+	c, err := NewWithTTL[int, int](64, time.Nanosecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 64; i++ {
+		c.Add(i, i)
+	}
+	time.Sleep(time.Millisecond)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 64; i++ {
+				c.Contains(i)
+				c.Peek(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,237 @@
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"reflect"
+)
+
+// Hasher computes a shard-selection hash for a key. Implementations
+// need not be cryptographically strong; they only need to distribute
+// keys roughly evenly across shards.
+type Hasher[K comparable] func(key K) uint64
+
+var shardedSeed = maphash.MakeSeed()
+
+// defaultHasher hashes strings directly through maphash and scrambles
+// integers with MurmurHash3's fmix64 finalizer, since masking them bit-
+// for-bit would send every key that shares its low bits (multiples of
+// the shard count, for instance) to the same shard. Every
+// other comparable type falls back to hashing its fmt.Sprintf
+// representation, since K carries no constraint beyond comparable to
+// dispatch on.
+func defaultHasher[K comparable]() Hasher[K] {
+	return func(key K) uint64 {
+		rv := reflect.ValueOf(key)
+		switch rv.Kind() {
+		case reflect.String:
+			var h maphash.Hash
+			h.SetSeed(shardedSeed)
+			h.WriteString(rv.String())
+			return h.Sum64()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return mixUint64(uint64(rv.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return mixUint64(rv.Uint())
+		default:
+			var h maphash.Hash
+			h.SetSeed(shardedSeed)
+			fmt.Fprintf(&h, "%v", key)
+			return h.Sum64()
+		}
+	}
+}
+
+// mixUint64 is MurmurHash3's fmix64 finalizer, reused here to scramble
+// plain integer keys before masking them down to a shard index.
+func mixUint64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// shardedOptions collects the NewSharded options below.
+type shardedOptions[K comparable, V any] struct {
+	hasher           Hasher[K]
+	weightLimit      uint64
+	weightCalculator func(value V) uint64
+	onEvicted        func(key K, value V)
+}
+
+// ShardedOption configures a ShardedCache constructed via NewSharded.
+type ShardedOption[K comparable, V any] func(*shardedOptions[K, V])
+
+// WithHasher overrides the hash function NewSharded uses to pick a
+// key's shard. The default hashes strings directly and scrambles
+// integers with a 64-bit finalizer; override it when K's built-in representation
+// would cluster keys onto the same few shards.
+func WithHasher[K comparable, V any](hasher Hasher[K]) ShardedOption[K, V] {
+	return func(o *shardedOptions[K, V]) {
+		o.hasher = hasher
+	}
+}
+
+// WithShardedWeightLimit is like NewWithWeightLimitAndEvict's weight
+// limit, except the total is divided proportionally across shards.
+func WithShardedWeightLimit[K comparable, V any](weightLimit uint64, weightCalculator func(value V) uint64) ShardedOption[K, V] {
+	return func(o *shardedOptions[K, V]) {
+		o.weightLimit = weightLimit
+		o.weightCalculator = weightCalculator
+	}
+}
+
+// WithShardedEvict registers an eviction callback invoked, outside of
+// the evicting shard's critical section, whenever any shard evicts an
+// entry.
+func WithShardedEvict[K comparable, V any](onEvicted func(key K, value V)) ShardedOption[K, V] {
+	return func(o *shardedOptions[K, V]) {
+		o.onEvicted = onEvicted
+	}
+}
+
+// ShardedCache fans keys across a fixed number of independent Cache
+// shards, each with its own lock, to reduce lock contention versus a
+// single Cache under concurrent access from many goroutines. It trades
+// strict global LRU ordering for scalability: eviction decisions are
+// made per-shard rather than across the whole cache.
+type ShardedCache[K comparable, V any] struct {
+	shards []*Cache[K, V]
+	mask   uint64
+	hasher Hasher[K]
+}
+
+// NewSharded creates a ShardedCache of totalSize entries split
+// proportionally across the given number of shards, which must be a
+// power of two so shard selection can mask the hash instead of taking
+// a remainder.
+func NewSharded[K comparable, V any](totalSize int, shards int, opts ...ShardedOption[K, V]) (*ShardedCache[K, V], error) {
+	if totalSize <= 0 {
+		return nil, errors.New("must provide a positive size")
+	}
+	if shards <= 0 || shards&(shards-1) != 0 {
+		return nil, errors.New("shards must be a power of two")
+	}
+	if totalSize < shards {
+		return nil, errors.New("totalSize must be at least shards")
+	}
+
+	o := shardedOptions[K, V]{hasher: defaultHasher[K]()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	sc := &ShardedCache[K, V]{
+		shards: make([]*Cache[K, V], shards),
+		mask:   uint64(shards - 1),
+		hasher: o.hasher,
+	}
+	for i := range sc.shards {
+		shardSize := partition(totalSize, shards, i)
+		var shardWeightLimit uint64
+		if o.weightLimit > 0 {
+			shardWeightLimit = partition64(o.weightLimit, uint64(shards), uint64(i))
+		}
+		shard, err := NewWithWeightLimitAndEvict(shardSize, shardWeightLimit, o.weightCalculator, o.onEvicted)
+		if err != nil {
+			return nil, err
+		}
+		sc.shards[i] = shard
+	}
+	return sc, nil
+}
+
+// partition divides total into shards pieces, handing the remainder to
+// the first shards so every unit of total is accounted for. Callers
+// must ensure total >= shards so no piece rounds down to zero.
+func partition(total, shards, i int) int {
+	return total/shards + boolToInt(i < total%shards)
+}
+
+func partition64(total, shards, i uint64) uint64 {
+	part := total / shards
+	if i < total%shards {
+		part++
+	}
+	return part
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// shardFor returns the shard key is assigned to.
+func (sc *ShardedCache[K, V]) shardFor(key K) *Cache[K, V] {
+	return sc.shards[sc.hasher(key)&sc.mask]
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+// It touches only the one shard key hashes to.
+func (sc *ShardedCache[K, V]) Add(key K, value V) (evicted bool) {
+	return sc.shardFor(key).Add(key, value)
+}
+
+// Get looks up a key's value from the cache.
+func (sc *ShardedCache[K, V]) Get(key K) (value V, ok bool) {
+	return sc.shardFor(key).Get(key)
+}
+
+// Contains checks if a key is in the cache, without updating the
+// recent-ness. An expired entry is removed and reported absent.
+func (sc *ShardedCache[K, V]) Contains(key K) bool {
+	return sc.shardFor(key).Contains(key)
+}
+
+// Peek returns the key value (or undefined if not found) without
+// updating the "recently used"-ness of the key.
+func (sc *ShardedCache[K, V]) Peek(key K) (value V, ok bool) {
+	return sc.shardFor(key).Peek(key)
+}
+
+// Remove removes the provided key from the cache.
+func (sc *ShardedCache[K, V]) Remove(key K) (present bool) {
+	return sc.shardFor(key).Remove(key)
+}
+
+// Purge clears every shard.
+func (sc *ShardedCache[K, V]) Purge() {
+	for _, shard := range sc.shards {
+		shard.Purge()
+	}
+}
+
+// Keys returns the keys of every shard concatenated together. Unlike
+// Cache.Keys, the result as a whole is not ordered oldest to newest,
+// since each shard tracks its own recency independently of the others.
+func (sc *ShardedCache[K, V]) Keys() []K {
+	keys := make([]K, 0, sc.Len())
+	for _, shard := range sc.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// Len returns the number of items across every shard.
+func (sc *ShardedCache[K, V]) Len() int {
+	total := 0
+	for _, shard := range sc.shards {
+		total += shard.Len()
+	}
+	return total
+}
+
+// WeightTotal returns the sum of the weight of all the entries across
+// every shard.
+func (sc *ShardedCache[K, V]) WeightTotal() uint64 {
+	var total uint64
+	for _, shard := range sc.shards {
+		total += shard.WeightTotal()
+	}
+	return total
+}
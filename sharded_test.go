@@ -0,0 +1,43 @@
+package lru
+
+import "testing"
+
+// BenchmarkCacheParallel exercises a single-lock Cache from multiple
+// goroutines, each cycling through the same range of keys, so b.N scales with
+// GOMAXPROCS. Compare against BenchmarkShardedCacheParallel to see what
+// splitting the lock across shards buys under contention.
+func BenchmarkCacheParallel(b *testing.B) {
+	c, err := New[int, int](1 << 16)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := i % (1 << 16)
+			c.Add(key, i)
+			c.Get(key)
+			i++
+		}
+	})
+}
+
+// BenchmarkShardedCacheParallel is BenchmarkCacheParallel's counterpart
+// for ShardedCache.
+func BenchmarkShardedCacheParallel(b *testing.B) {
+	sc, err := NewSharded[int, int](1<<16, 64)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := i % (1 << 16)
+			sc.Add(key, i)
+			sc.Get(key)
+			i++
+		}
+	})
+}
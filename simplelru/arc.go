@@ -0,0 +1,409 @@
+package simplelru
+
+import (
+	"errors"
+)
+
+// ARC implements a non-thread safe Adaptive Replacement Cache (ARC). ARC
+// keeps a resident set split across T1 (entries seen once recently) and
+// T2 (entries seen at least twice), each backed by a same-sized ghost
+// list of keys recently evicted from it (B1, B2 respectively), and
+// adapts the target T1 size p based on which ghost list is producing
+// hits. This makes it considerably more resistant to the scan patterns
+// that defeat plain LRU.
+//
+// T1/T2/B1/B2 are themselves backed by LRU so ARC can reuse its list
+// bookkeeping; their own size-driven eviction is never expected to fire,
+// since ARC always runs REPLACE to keep |T1|+|T2| <= size before an
+// insertion would exceed it.
+type ARC[K comparable, V any] struct {
+	size int
+	p    int
+
+	t1 *LRU[K, V]
+	t2 *LRU[K, V]
+	b1 *LRU[K, struct{}]
+	b2 *LRU[K, struct{}]
+
+	onEvict EvictCallback[K, V]
+
+	weightTotal      uint64
+	weightLimit      uint64
+	weightCalculator WeightCalculator[V]
+}
+
+// NewARC constructs an ARC of the given size.
+func NewARC[K comparable, V any](size int, onEvict EvictCallback[K, V]) (*ARC[K, V], error) {
+	return NewARCWithWeightLimit(size, 0, nil, onEvict)
+}
+
+// NewARCWithWeightLimit constructs a fixed size ARC with the weight
+// limit and given eviction callback.
+func NewARCWithWeightLimit[K comparable, V any](
+	size int,
+	weightLimit uint64,
+	weightCalculator WeightCalculator[V],
+	onEvict EvictCallback[K, V],
+) (*ARC[K, V], error) {
+	if size <= 0 {
+		return nil, errors.New("must provide a positive size")
+	}
+
+	t1, err := NewLRU[K, V](size, nil)
+	if err != nil {
+		return nil, err
+	}
+	t2, err := NewLRU[K, V](size, nil)
+	if err != nil {
+		return nil, err
+	}
+	b1, err := NewLRU[K, struct{}](size, nil)
+	if err != nil {
+		return nil, err
+	}
+	b2, err := NewLRU[K, struct{}](size, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ARC[K, V]{
+		size: size,
+		t1:   t1,
+		t2:   t2,
+		b1:   b1,
+		b2:   b2,
+
+		onEvict: onEvict,
+
+		weightLimit:      weightLimit,
+		weightCalculator: weightCalculator,
+	}, nil
+}
+
+// Purge is used to completely clear the cache.
+func (c *ARC[K, V]) Purge() {
+	if c.onEvict != nil {
+		for _, k := range c.t1.Keys() {
+			if v, ok := c.t1.Peek(k); ok {
+				c.onEvict(k, v)
+			}
+		}
+		for _, k := range c.t2.Keys() {
+			if v, ok := c.t2.Peek(k); ok {
+				c.onEvict(k, v)
+			}
+		}
+	}
+	c.t1.Purge()
+	c.t2.Purge()
+	c.b1.Purge()
+	c.b2.Purge()
+	c.p = 0
+	c.weightTotal = 0
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *ARC[K, V]) Add(key K, value V) (evicted bool) {
+	// Case I: already resident in T1 - promote to T2 MRU.
+	if old, ok := c.t1.Peek(key); ok {
+		c.weightTotal -= c.weightOf(old)
+		c.t1.Remove(key)
+		c.addResident(c.t2, key, value)
+		return c.enforceWeight()
+	}
+	// Already resident in T2 - update value, stays T2 MRU.
+	if _, ok := c.t2.Peek(key); ok {
+		c.addResident(c.t2, key, value)
+		return c.enforceWeight()
+	}
+
+	// Case II: ghost hit in B1 - grow p toward T1, replace, then admit to
+	// T2 MRU.
+	if _, ok := c.b1.Peek(key); ok {
+		ratio := 1
+		if c.b1.Len() > 0 {
+			ratio = maxInt(1, c.b2.Len()/c.b1.Len())
+		}
+		c.p = minInt(c.p+ratio, c.size)
+		c.b1.Remove(key)
+		evicted = c.replace(false)
+		c.addResident(c.t2, key, value)
+		return c.enforceWeight() || evicted
+	}
+
+	// Case III: ghost hit in B2 - shrink p toward T2, replace, then admit
+	// to T2 MRU.
+	if _, ok := c.b2.Peek(key); ok {
+		ratio := 1
+		if c.b2.Len() > 0 {
+			ratio = maxInt(1, c.b1.Len()/c.b2.Len())
+		}
+		if ratio > c.p {
+			c.p = 0
+		} else {
+			c.p -= ratio
+		}
+		c.b2.Remove(key)
+		evicted = c.replace(true)
+		c.addResident(c.t2, key, value)
+		return c.enforceWeight() || evicted
+	}
+
+	// Case IV: fresh miss.
+	t1Len, b1Len := c.t1.Len(), c.b1.Len()
+	switch {
+	case t1Len+b1Len == c.size:
+		if t1Len < c.size {
+			c.b1.RemoveOldest()
+			evicted = c.replace(false)
+		} else {
+			evicted = c.evictResidentOldest(c.t1)
+		}
+	case t1Len+b1Len < c.size:
+		total := t1Len + c.t2.Len() + b1Len + c.b2.Len()
+		if total >= c.size {
+			if total == 2*c.size {
+				c.b2.RemoveOldest()
+			}
+			evicted = c.replace(false)
+		}
+	}
+	c.addResident(c.t1, key, value)
+	return c.enforceWeight() || evicted
+}
+
+// Get looks up a key's value from the cache. A hit in T1 promotes the
+// entry to T2 MRU; a hit in T2 moves it to T2 MRU.
+func (c *ARC[K, V]) Get(key K) (value V, ok bool) {
+	if v, found := c.t1.Peek(key); found {
+		c.weightTotal -= c.weightOf(v)
+		c.t1.Remove(key)
+		c.addResident(c.t2, key, v)
+		return v, true
+	}
+	return c.t2.Get(key)
+}
+
+// Contains checks if a key is resident in the cache, without updating
+// its recency or treating a ghost hit as present.
+func (c *ARC[K, V]) Contains(key K) bool {
+	return c.t1.Contains(key) || c.t2.Contains(key)
+}
+
+// Peek returns the key's value (or undefined if not resident) without
+// updating its recency.
+func (c *ARC[K, V]) Peek(key K) (value V, ok bool) {
+	if v, found := c.t1.Peek(key); found {
+		return v, true
+	}
+	return c.t2.Peek(key)
+}
+
+// Remove removes the provided key from the cache (resident or ghost),
+// returning if the key was present in either form.
+func (c *ARC[K, V]) Remove(key K) (present bool) {
+	if v, ok := c.t1.Peek(key); ok {
+		c.weightTotal -= c.weightOf(v)
+		c.t1.Remove(key)
+		if c.onEvict != nil {
+			c.onEvict(key, v)
+		}
+		return true
+	}
+	if v, ok := c.t2.Peek(key); ok {
+		c.weightTotal -= c.weightOf(v)
+		c.t2.Remove(key)
+		if c.onEvict != nil {
+			c.onEvict(key, v)
+		}
+		return true
+	}
+	if c.b1.Contains(key) {
+		c.b1.Remove(key)
+		return true
+	}
+	if c.b2.Contains(key) {
+		c.b2.Remove(key)
+		return true
+	}
+	return false
+}
+
+// RemoveOldest removes the least recently used entry of T1 when T1 is
+// non-empty and holds at least p entries, otherwise that of T2 (falling
+// back to T1 when T2 is empty). When |T1| equals p this takes from T1,
+// where replace(false) takes from T2; unlike replace, it does not add the
+// removed key to a ghost list.
+func (c *ARC[K, V]) RemoveOldest() (key K, value V, ok bool) {
+	if c.t1.Len() > 0 && c.t1.Len() >= c.p {
+		if k, v, found := c.t1.RemoveOldest(); found {
+			return c.finishRemoveOldest(k, v)
+		}
+	}
+	if k, v, found := c.t2.RemoveOldest(); found {
+		return c.finishRemoveOldest(k, v)
+	}
+	if k, v, found := c.t1.RemoveOldest(); found {
+		return c.finishRemoveOldest(k, v)
+	}
+	return
+}
+
+func (c *ARC[K, V]) finishRemoveOldest(key K, value V) (K, V, bool) {
+	c.weightTotal -= c.weightOf(value)
+	if c.onEvict != nil {
+		c.onEvict(key, value)
+	}
+	return key, value, true
+}
+
+// evictResidentOldest removes list's oldest entry with no ghost-list
+// admission, for the Case IV situation where T1 is already full of
+// resident entries and B1 is empty (so there's nothing to turn into a
+// ghost). It still needs the same weight/callback bookkeeping as replace.
+func (c *ARC[K, V]) evictResidentOldest(list *LRU[K, V]) bool {
+	k, v, ok := list.RemoveOldest()
+	if !ok {
+		return false
+	}
+	c.weightTotal -= c.weightOf(v)
+	if c.onEvict != nil {
+		c.onEvict(k, v)
+	}
+	return true
+}
+
+// GetOldest returns the resident entry RemoveOldest would remove.
+func (c *ARC[K, V]) GetOldest() (key K, value V, ok bool) {
+	if c.t1.Len() > 0 && c.t1.Len() >= c.p {
+		if k, v, found := c.t1.GetOldest(); found {
+			return k, v, true
+		}
+	}
+	if k, v, found := c.t2.GetOldest(); found {
+		return k, v, true
+	}
+	return c.t1.GetOldest()
+}
+
+// Keys returns the resident keys, T1 (oldest to newest) followed by T2
+// (oldest to newest). Unlike LRU.Keys, this isn't a single recency
+// order: ARC has no total order across T1 and T2.
+func (c *ARC[K, V]) Keys() []K {
+	keys := make([]K, 0, c.t1.Len()+c.t2.Len())
+	keys = append(keys, c.t1.Keys()...)
+	keys = append(keys, c.t2.Keys()...)
+	return keys
+}
+
+// Len returns the number of resident items in the cache.
+func (c *ARC[K, V]) Len() int {
+	return c.t1.Len() + c.t2.Len()
+}
+
+// WeightTotal returns the sum of the weight of all resident entries.
+func (c *ARC[K, V]) WeightTotal() uint64 {
+	return c.weightTotal
+}
+
+// Resize changes the cache size.
+func (c *ARC[K, V]) Resize(size int) (evicted int) {
+	c.size = size
+	if c.p > size {
+		c.p = size
+	}
+	for c.t1.Len()+c.t2.Len() > c.size {
+		if !c.replace(false) {
+			break
+		}
+		evicted++
+	}
+	c.t1.Resize(size)
+	c.t2.Resize(size)
+	c.b1.Resize(size)
+	c.b2.Resize(size)
+	return evicted
+}
+
+// ResetWeightLimit changes the weight limit.
+func (c *ARC[K, V]) ResetWeightLimit(weightLimit uint64) (evicted int) {
+	c.weightLimit = weightLimit
+	for c.weightTotal > c.weightLimit && c.t1.Len()+c.t2.Len() > 0 {
+		if !c.replace(false) {
+			break
+		}
+		evicted++
+	}
+	return evicted
+}
+
+// replace evicts one resident entry into its ghost list, per the ARC
+// REPLACE rule: prefer T1 once it exceeds the target p (or is tied with
+// it on a B2 hit), otherwise take from T2.
+func (c *ARC[K, V]) replace(inB2 bool) bool {
+	t1Len := c.t1.Len()
+	if t1Len > 0 && (t1Len > c.p || (t1Len == c.p && inB2)) {
+		if k, v, ok := c.t1.RemoveOldest(); ok {
+			c.weightTotal -= c.weightOf(v)
+			c.b1.Add(k, struct{}{})
+			if c.onEvict != nil {
+				c.onEvict(k, v)
+			}
+			return true
+		}
+		return false
+	}
+	if k, v, ok := c.t2.RemoveOldest(); ok {
+		c.weightTotal -= c.weightOf(v)
+		c.b2.Add(k, struct{}{})
+		if c.onEvict != nil {
+			c.onEvict(k, v)
+		}
+		return true
+	}
+	return false
+}
+
+// addResident adds key/value to one of T1/T2, keeping weightTotal in
+// sync (list is never nil: it's always c.t1 or c.t2).
+func (c *ARC[K, V]) addResident(list *LRU[K, V], key K, value V) {
+	if old, ok := list.Peek(key); ok {
+		c.weightTotal -= c.weightOf(old)
+	}
+	list.Add(key, value)
+	c.weightTotal += c.weightOf(value)
+}
+
+// enforceWeight evicts, beyond whatever REPLACE already did, until the
+// weight limit is satisfied.
+func (c *ARC[K, V]) enforceWeight() bool {
+	var evicted bool
+	for c.weightTotal > c.weightLimit && c.t1.Len()+c.t2.Len() > 0 {
+		if !c.replace(false) {
+			break
+		}
+		evicted = true
+	}
+	return evicted
+}
+
+func (c *ARC[K, V]) weightOf(v V) uint64 {
+	if c.weightCalculator == nil {
+		return 0
+	}
+	return c.weightCalculator(v)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,95 @@
+package simplelru
+
+import "testing"
+
+// TestARCEvictionCallbackFires checks that filling an ARC past its size
+// fires the eviction callback on the resident entries it evicts. Adding
+// only fresh keys keeps B1 empty, so every eviction here goes through the
+// T1-full/B1-empty path (evictResidentOldest), not through replace.
+func TestARCEvictionCallbackFires(t *testing.T) {
+	var calls int
+	c, err := NewARC[int, int](2, func(k, v int) { calls++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		c.Add(i, i)
+	}
+
+	if calls == 0 {
+		t.Fatal("expected eviction callback to fire at least once")
+	}
+	if c.Len() != 2 {
+		t.Fatalf("want 2 resident entries, got %d", c.Len())
+	}
+}
+
+// TestARCWeightPromotionDoesNotDoubleCount checks that promoting an
+// entry from T1 to T2 - via either Add re-inserting an existing T1 key,
+// or Get hitting one - leaves WeightTotal unchanged, since the entry
+// itself hasn't grown.
+func TestARCWeightPromotionDoesNotDoubleCount(t *testing.T) {
+	weightOf := func(v string) uint64 { return uint64(len(v)) }
+
+	t.Run("promote via Get", func(t *testing.T) {
+		c, err := NewARCWithWeightLimit[string, string](10, 100, weightOf, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Add("a", "hello")
+		if c.WeightTotal() != 5 {
+			t.Fatalf("after add: want weight 5, got %d", c.WeightTotal())
+		}
+		if _, ok := c.Get("a"); !ok {
+			t.Fatal("expected key a to be present")
+		}
+		if c.WeightTotal() != 5 {
+			t.Fatalf("after promoting Get: want weight 5, got %d", c.WeightTotal())
+		}
+	})
+
+	t.Run("promote via Add", func(t *testing.T) {
+		c, err := NewARCWithWeightLimit[string, string](10, 100, weightOf, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Add("a", "hello")
+		c.Add("a", "hello") // still resident in T1: re-Add promotes to T2
+		if c.WeightTotal() != 5 {
+			t.Fatalf("after re-add promoting to T2: want weight 5, got %d", c.WeightTotal())
+		}
+	})
+}
+
+// TestARCGhostHitsAdaptP checks the adaptive part of ARC: a B1 ghost hit
+// should grow p toward T1.
+func TestARCGhostHitsAdaptP(t *testing.T) {
+	c, err := NewARC[int, int](2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fill T1, then promote one entry to T2 so a subsequent fresh miss
+	// goes through replace() (Case IV's "total >= size" branch) rather
+	// than the ghost-less evictResidentOldest path.
+	c.Add(1, 1)
+	c.Add(2, 2)
+	if _, ok := c.Get(1); !ok {
+		t.Fatal("expected key 1 to be present")
+	}
+	c.Add(3, 3)
+
+	if c.b1.Len() == 0 {
+		t.Fatal("expected an entry to have been pushed into B1")
+	}
+	pBefore := c.p
+
+	// Re-Add the key that's now a ghost in B1: this should grow p.
+	ghostKey := c.b1.Keys()[0]
+	c.Add(ghostKey, ghostKey)
+
+	if c.p <= pBefore {
+		t.Fatalf("expected p to grow on a B1 ghost hit: before=%d after=%d", pBefore, c.p)
+	}
+}
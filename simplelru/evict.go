@@ -0,0 +1,37 @@
+package simplelru
+
+// EvictReason describes why an entry left the cache.
+type EvictReason int
+
+const (
+	// ReasonCapacity means the entry was evicted to keep the cache at or
+	// under its configured size.
+	ReasonCapacity EvictReason = iota
+	// ReasonWeight means the entry was evicted to keep the cache at or
+	// under its configured weight limit.
+	ReasonWeight
+	// ReasonExpired means the entry's TTL elapsed.
+	ReasonExpired
+	// ReasonRemoved means the entry was removed by an explicit call such
+	// as Remove or RemoveOldest.
+	ReasonRemoved
+	// ReasonReplaced means the entry was overwritten by a new value for
+	// the same key before it was otherwise evicted or expired.
+	ReasonReplaced
+)
+
+// EvictCallbackV2 is used to get a callback when a cache entry is
+// evicted, along with the reason it left the cache.
+type EvictCallbackV2[K comparable, V any] func(key K, value V, reason EvictReason)
+
+// adaptEvictCallback wraps an EvictCallback in an EvictCallbackV2 that
+// discards the reason, so the pre-existing callback signature keeps
+// working unchanged.
+func adaptEvictCallback[K comparable, V any](onEvict EvictCallback[K, V]) EvictCallbackV2[K, V] {
+	if onEvict == nil {
+		return nil
+	}
+	return func(key K, value V, _ EvictReason) {
+		onEvict(key, value)
+	}
+}
@@ -0,0 +1,37 @@
+package simplelru
+
+// expireHeap is a container/heap.Interface implementation over the
+// entries that carry a TTL, ordered by expiresAt ascending, so
+// DeleteExpired only has to look at (and pop) entries that have
+// actually expired instead of walking the whole cache.
+type expireHeap[K comparable, V any] struct {
+	items []*entry[K, V]
+}
+
+func (h *expireHeap[K, V]) Len() int { return len(h.items) }
+
+func (h *expireHeap[K, V]) Less(i, j int) bool {
+	return h.items[i].expiresAt.Before(h.items[j].expiresAt)
+}
+
+func (h *expireHeap[K, V]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].heapIndex = i
+	h.items[j].heapIndex = j
+}
+
+func (h *expireHeap[K, V]) Push(x any) {
+	e := x.(*entry[K, V])
+	e.heapIndex = len(h.items)
+	h.items = append(h.items, e)
+}
+
+func (h *expireHeap[K, V]) Pop() any {
+	old := h.items
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.heapIndex = -1
+	h.items = old[:n-1]
+	return e
+}
@@ -0,0 +1,96 @@
+package simplelru
+
+import "time"
+
+// entry is an element of a lruList.
+type entry[K comparable, V any] struct {
+	key    K
+	value  V
+	weight uint64
+
+	// expiresAt is the zero Time when the entry has no TTL. heapIndex is
+	// its position in the owning LRU's expireHeap, or -1 when the entry
+	// isn't tracked there (no TTL, or already popped).
+	expiresAt time.Time
+	heapIndex int
+
+	prev, next *entry[K, V]
+	list       *lruList[K, V]
+}
+
+// prevEntry returns the entry ahead of e in the list (i.e. more recently
+// used), or nil if e is already the front-most entry.
+func (e *entry[K, V]) prevEntry() *entry[K, V] {
+	if p := e.prev; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// lruList is an intrusive doubly linked list, ordered from most recently
+// used (front) to least recently used (back), backed by a sentinel root
+// entry so insert/remove never need to special-case an empty list.
+type lruList[K comparable, V any] struct {
+	root entry[K, V]
+	len  int
+}
+
+// newList returns an initialized list.
+func newList[K comparable, V any]() *lruList[K, V] {
+	l := &lruList[K, V]{}
+	l.init()
+	return l
+}
+
+// init resets the list to empty.
+func (l *lruList[K, V]) init() {
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.len = 0
+}
+
+// length returns the number of entries in the list.
+func (l *lruList[K, V]) length() int {
+	return l.len
+}
+
+// back returns the least recently used entry, or nil if the list is empty.
+func (l *lruList[K, V]) back() *entry[K, V] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// pushFront inserts a new entry for key/value at the front of the list.
+func (l *lruList[K, V]) pushFront(key K, value V) *entry[K, V] {
+	e := &entry[K, V]{key: key, value: value, list: l, heapIndex: -1}
+	e.prev = &l.root
+	e.next = l.root.next
+	e.prev.next = e
+	e.next.prev = e
+	l.len++
+	return e
+}
+
+// moveToFront moves e to the front of the list.
+func (l *lruList[K, V]) moveToFront(e *entry[K, V]) {
+	if l.root.next == e {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = &l.root
+	e.next = l.root.next
+	e.prev.next = e
+	e.next.prev = e
+}
+
+// remove unlinks e from the list.
+func (l *lruList[K, V]) remove(e *entry[K, V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.next = nil
+	e.prev = nil
+	l.len--
+}
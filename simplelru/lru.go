@@ -1,7 +1,9 @@
 package simplelru
 
 import (
+	"container/heap"
 	"errors"
+	"time"
 )
 
 // EvictCallback is used to get a callback when a cache entry is evicted
@@ -15,11 +17,16 @@ type LRU[K comparable, V any] struct {
 	size      int
 	evictList *lruList[K, V]
 	items     map[K]*entry[K, V]
-	onEvict   EvictCallback[K, V]
+	onEvict   EvictCallbackV2[K, V]
 
 	weightTotal      uint64
 	weightLimit      uint64
 	weightCalculator WeightCalculator[V]
+
+	// ttl is the default time-to-live applied by Add; AddWithTTL can
+	// override it per entry. Zero means entries never expire.
+	ttl        time.Duration
+	expireHeap *expireHeap[K, V]
 }
 
 // NewLRU constructs an LRU of the given size
@@ -32,6 +39,24 @@ func NewLRUWithWeightLimit[K comparable, V any](
 	weightLimit uint64,
 	weightCalculator WeightCalculator[V],
 	onEvict EvictCallback[K, V],
+) (*LRU[K, V], error) {
+	return newLRU(size, weightLimit, weightCalculator, 0, adaptEvictCallback(onEvict))
+}
+
+// NewLRUWithTTL constructs a fixed size LRU whose entries expire ttl
+// after being added, unless overridden per entry via AddWithTTL. A
+// non-positive ttl means entries never expire on their own, matching
+// NewLRU.
+func NewLRUWithTTL[K comparable, V any](size int, ttl time.Duration, onEvict EvictCallback[K, V]) (*LRU[K, V], error) {
+	return newLRU(size, 0, nil, ttl, adaptEvictCallback(onEvict))
+}
+
+func newLRU[K comparable, V any](
+	size int,
+	weightLimit uint64,
+	weightCalculator WeightCalculator[V],
+	ttl time.Duration,
+	onEvict EvictCallbackV2[K, V],
 ) (*LRU[K, V], error) {
 	if size <= 0 {
 		return nil, errors.New("must provide a positive size")
@@ -45,39 +70,153 @@ func NewLRUWithWeightLimit[K comparable, V any](
 
 		weightLimit:      weightLimit,
 		weightCalculator: weightCalculator,
+
+		ttl:        ttl,
+		expireHeap: &expireHeap[K, V]{},
 	}
 	return c, nil
 }
 
-// Purge is used to completely clear the cache.
+// Purge is used to completely clear the cache. Rather than walking every
+// entry and invoking the eviction callback synchronously under the
+// caller's lock, it swaps in an empty index/list in O(1) and, if an
+// eviction callback is configured, hands the discarded entries to a
+// background goroutine that drains them and fires the callback outside
+// the critical section.
 func (c *LRU[K, V]) Purge() {
-	for k, v := range c.items {
-		if c.onEvict != nil {
-			c.onEvict(k, v.value)
+	oldItems := c.items
+	c.items = make(map[K]*entry[K, V])
+	c.evictList = newList[K, V]()
+	c.expireHeap = &expireHeap[K, V]{}
+	c.weightTotal = 0
+
+	if c.onEvict != nil && len(oldItems) > 0 {
+		onEvict := c.onEvict
+		go func() {
+			for _, ent := range oldItems {
+				onEvict(ent.key, ent.value, ReasonRemoved)
+			}
+		}()
+	}
+}
+
+// PurgeCollecting is like Purge, but returns the discarded entries
+// directly instead of invoking the eviction callback itself. It's for
+// callers that want to control how and when the callback fires (e.g.
+// to attribute it to a specific context) without Purge's own background
+// goroutine redelivering the same entries a second time.
+func (c *LRU[K, V]) PurgeCollecting() map[K]V {
+	oldItems := c.items
+	c.items = make(map[K]*entry[K, V])
+	c.evictList = newList[K, V]()
+	c.expireHeap = &expireHeap[K, V]{}
+	c.weightTotal = 0
+
+	if len(oldItems) == 0 {
+		return nil
+	}
+	discarded := make(map[K]V, len(oldItems))
+	for k, ent := range oldItems {
+		discarded[k] = ent.value
+	}
+	return discarded
+}
+
+// PurgeMatching removes every entry for which matches returns true.
+// Eviction callbacks, if any, fire from a background goroutine outside
+// the critical section, same as Purge.
+func (c *LRU[K, V]) PurgeMatching(matches func(key K, value V) bool) {
+	var removed map[K]*entry[K, V]
+	for e := c.evictList.back(); e != nil; {
+		prev := e.prevEntry()
+		if matches(e.key, e.value) {
+			c.evictList.remove(e)
+			delete(c.items, e.key)
+			c.weightTotal -= e.weight
+			if e.heapIndex != -1 {
+				heap.Remove(c.expireHeap, e.heapIndex)
+			}
+			if c.onEvict != nil {
+				if removed == nil {
+					removed = make(map[K]*entry[K, V])
+				}
+				removed[e.key] = e
+			}
+		}
+		e = prev
+	}
+
+	if len(removed) > 0 {
+		onEvict := c.onEvict
+		go func() {
+			for _, ent := range removed {
+				onEvict(ent.key, ent.value, ReasonRemoved)
+			}
+		}()
+	}
+}
+
+// PurgeMatchingCollecting is like PurgeMatching, but returns the
+// discarded entries directly instead of invoking the eviction callback
+// itself, for the same reason PurgeCollecting exists.
+func (c *LRU[K, V]) PurgeMatchingCollecting(matches func(key K, value V) bool) map[K]V {
+	var discarded map[K]V
+	for e := c.evictList.back(); e != nil; {
+		prev := e.prevEntry()
+		if matches(e.key, e.value) {
+			c.evictList.remove(e)
+			delete(c.items, e.key)
+			c.weightTotal -= e.weight
+			if e.heapIndex != -1 {
+				heap.Remove(c.expireHeap, e.heapIndex)
+			}
+			if discarded == nil {
+				discarded = make(map[K]V)
+			}
+			discarded[e.key] = e.value
 		}
-		delete(c.items, k)
+		e = prev
 	}
-	c.evictList.init()
+	return discarded
 }
 
-// Add adds a value to the cache.  Returns true if an eviction occurred.
+// Add adds a value to the cache, using the cache's default TTL (if any).
+// Returns true if an eviction occurred.
 func (c *LRU[K, V]) Add(key K, value V) (evicted bool) {
+	return c.AddWithTTL(key, value, c.ttl)
+}
+
+// AddWithTTL adds a value to the cache with a per-entry TTL override. A
+// non-positive ttl means the entry never expires on its own. Returns
+// true if an eviction occurred.
+func (c *LRU[K, V]) AddWithTTL(key K, value V, ttl time.Duration) (evicted bool) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
 	// Check for existing item
 	if ent, ok := c.items[key]; ok {
+		oldValue := ent.value
 		c.evictList.moveToFront(ent)
 		ent.value = value
+		c.setExpiry(ent, expiresAt)
 
 		if c.weightCalculator != nil {
 			c.weightTotal -= ent.weight
 			ent.weight = c.weightCalculator(value)
 			c.weightTotal += ent.weight
 		}
+		if c.onEvict != nil {
+			c.onEvict(key, oldValue, ReasonReplaced)
+		}
 		return c.checkEvict() > 0
 	}
 
 	// Add new item
 	ent := c.evictList.pushFront(key, value)
 	c.items[key] = ent
+	c.setExpiry(ent, expiresAt)
 
 	if c.weightCalculator != nil {
 		ent.weight = c.weightCalculator(value)
@@ -86,37 +225,72 @@ func (c *LRU[K, V]) Add(key K, value V) (evicted bool) {
 	return c.checkEvict() > 0
 }
 
+// setExpiry updates ent's expiresAt and its membership in expireHeap to
+// match.
+func (c *LRU[K, V]) setExpiry(ent *entry[K, V], expiresAt time.Time) {
+	ent.expiresAt = expiresAt
+	switch {
+	case expiresAt.IsZero() && ent.heapIndex != -1:
+		heap.Remove(c.expireHeap, ent.heapIndex)
+	case !expiresAt.IsZero() && ent.heapIndex == -1:
+		heap.Push(c.expireHeap, ent)
+	case !expiresAt.IsZero():
+		heap.Fix(c.expireHeap, ent.heapIndex)
+	}
+}
+
 // Get looks up a key's value from the cache.
 func (c *LRU[K, V]) Get(key K) (value V, ok bool) {
-	if ent, ok := c.items[key]; ok {
-		c.evictList.moveToFront(ent)
-		return ent.value, true
+	ent, found := c.items[key]
+	if !found {
+		return
 	}
-	return
+	if c.expired(ent) {
+		c.removeElement(ent, ReasonExpired)
+		return value, false
+	}
+	c.evictList.moveToFront(ent)
+	return ent.value, true
 }
 
 // Contains checks if a key is in the cache, without updating the recent-ness
 // or deleting it for being stale.
 func (c *LRU[K, V]) Contains(key K) (ok bool) {
-	_, ok = c.items[key]
-	return ok
+	ent, found := c.items[key]
+	if !found {
+		return false
+	}
+	if c.expired(ent) {
+		c.removeElement(ent, ReasonExpired)
+		return false
+	}
+	return true
 }
 
 // Peek returns the key value (or undefined if not found) without updating
 // the "recently used"-ness of the key.
 func (c *LRU[K, V]) Peek(key K) (value V, ok bool) {
-	var ent *entry[K, V]
-	if ent, ok = c.items[key]; ok {
-		return ent.value, true
+	ent, found := c.items[key]
+	if !found {
+		return
 	}
-	return
+	if c.expired(ent) {
+		c.removeElement(ent, ReasonExpired)
+		return value, false
+	}
+	return ent.value, true
+}
+
+// expired reports whether ent's TTL has elapsed.
+func (c *LRU[K, V]) expired(ent *entry[K, V]) bool {
+	return !ent.expiresAt.IsZero() && time.Now().After(ent.expiresAt)
 }
 
 // Remove removes the provided key from the cache, returning if the
 // key was contained.
 func (c *LRU[K, V]) Remove(key K) (present bool) {
 	if ent, ok := c.items[key]; ok {
-		c.removeElement(ent)
+		c.removeElement(ent, ReasonRemoved)
 		return true
 	}
 	return false
@@ -125,8 +299,9 @@ func (c *LRU[K, V]) Remove(key K) (present bool) {
 // RemoveOldest removes the oldest item from the cache.
 func (c *LRU[K, V]) RemoveOldest() (key K, value V, ok bool) {
 	if ent := c.evictList.back(); ent != nil {
-		c.removeElement(ent)
-		return ent.key, ent.value, true
+		key, value = ent.key, ent.value
+		c.removeElement(ent, ReasonRemoved)
+		return key, value, true
 	}
 	return
 }
@@ -172,23 +347,47 @@ func (c *LRU[K, V]) ResetWeightLimit(weightLimit uint64) (evicted int) {
 	return c.checkEvict()
 }
 
+// DeleteExpired eagerly reclaims every currently expired entry. It costs
+// O(k log n), where k is the number of entries actually expired, since
+// it only pops the head of the expiry heap rather than scanning the
+// whole cache. It returns the number of entries removed.
+func (c *LRU[K, V]) DeleteExpired() (removed int) {
+	now := time.Now()
+	for c.expireHeap.Len() > 0 {
+		ent := c.expireHeap.items[0]
+		if ent.expiresAt.After(now) {
+			break
+		}
+		c.removeElement(ent, ReasonExpired)
+		removed++
+	}
+	return removed
+}
+
 // checkEvict removes the oldest items unit size and weightLimit are all safe
 func (c *LRU[K, V]) checkEvict() int {
 	var evicted int
 	for c.evictList.length() > c.size || c.weightTotal > c.weightLimit {
+		reason := ReasonCapacity
+		if c.evictList.length() <= c.size {
+			reason = ReasonWeight
+		}
 		ent := c.evictList.back() // never be nil
-		c.removeElement(ent)
+		c.removeElement(ent, reason)
 		evicted++
 	}
 	return evicted
 }
 
 // removeElement is used to remove a given list element from the cache
-func (c *LRU[K, V]) removeElement(e *entry[K, V]) {
+func (c *LRU[K, V]) removeElement(e *entry[K, V], reason EvictReason) {
 	c.evictList.remove(e)
 	delete(c.items, e.key)
 	c.weightTotal -= e.weight
+	if e.heapIndex != -1 {
+		heap.Remove(c.expireHeap, e.heapIndex)
+	}
 	if c.onEvict != nil {
-		c.onEvict(e.key, e.value)
+		c.onEvict(e.key, e.value, reason)
 	}
 }
@@ -0,0 +1,97 @@
+package simplelru
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLRUPurgeMatching checks that PurgeMatching removes exactly the
+// entries the predicate selects, leaves the rest resident and still
+// gettable, and fires the eviction callback for each removed entry.
+// The callback fires from a background goroutine (see PurgeMatching's
+// doc comment), so the test waits on a channel rather than reading a
+// plain map right after the call returns.
+func TestLRUPurgeMatching(t *testing.T) {
+	evicted := make(chan int, 5)
+	c, err := NewLRU[int, int](10, func(k, v int) { evicted <- k })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 5; i++ {
+		c.Add(i, i*10)
+	}
+
+	c.PurgeMatching(func(key, value int) bool { return key%2 == 0 })
+
+	got := make(map[int]bool)
+	for i := 0; i < 3; i++ {
+		select {
+		case k := <-evicted:
+			got[k] = true
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for eviction callback")
+		}
+	}
+	for _, k := range []int{0, 2, 4} {
+		if !got[k] {
+			t.Fatalf("expected key %d to be evicted, got %v", k, got)
+		}
+	}
+	for _, k := range []int{1, 3} {
+		if v, ok := c.Get(k); !ok || v != k*10 {
+			t.Fatalf("expected key %d to survive with value %d, got %d ok=%v", k, k*10, v, ok)
+		}
+	}
+	if c.Len() != 2 {
+		t.Fatalf("want 2 entries remaining, got %d", c.Len())
+	}
+}
+
+// TestLRUPurgeMatchingCollecting checks the Collecting variant returns
+// the discarded entries directly and doesn't also invoke onEvict.
+func TestLRUPurgeMatchingCollecting(t *testing.T) {
+	var calls int
+	c, err := NewLRU[int, int](10, func(k, v int) { calls++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 4; i++ {
+		c.Add(i, i)
+	}
+
+	discarded := c.PurgeMatchingCollecting(func(key, value int) bool { return key < 2 })
+
+	if len(discarded) != 2 || discarded[0] != 0 || discarded[1] != 1 {
+		t.Fatalf("want discarded={0:0,1:1}, got %v", discarded)
+	}
+	if calls != 0 {
+		t.Fatalf("PurgeMatchingCollecting must not invoke onEvict itself, got %d calls", calls)
+	}
+	if c.Len() != 2 {
+		t.Fatalf("want 2 entries remaining, got %d", c.Len())
+	}
+}
+
+// TestLRUPurgeThenReuseKey checks that after Purge, re-adding a
+// previously purged key behaves like a fresh insert rather than
+// resurrecting the old entry.
+func TestLRUPurgeThenReuseKey(t *testing.T) {
+	c, err := NewLRU[string, int](10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Add("a", 1)
+	c.Purge()
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected purged key to be gone")
+	}
+
+	c.Add("a", 2)
+	if v, ok := c.Get("a"); !ok || v != 2 {
+		t.Fatalf("want a=2 after re-add, got %d ok=%v", v, ok)
+	}
+}
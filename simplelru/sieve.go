@@ -0,0 +1,275 @@
+package simplelru
+
+import (
+	"errors"
+)
+
+// sieveEntry is a node in the list maintained by SIEVE.
+type sieveEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	weight  uint64
+	visited bool
+	prev    *sieveEntry[K, V] // toward head (most recently inserted)
+	next    *sieveEntry[K, V] // toward tail (oldest)
+}
+
+// SIEVE implements a non-thread safe fixed size cache using the SIEVE
+// eviction algorithm (https://sievecache.com). New items are inserted at
+// the head with visited cleared. A Get only flips the visited bit on the
+// entry in place; it never splices the list, which keeps the hit path
+// O(1) with no list maintenance. Eviction is driven by a "hand" that
+// starts at the tail and walks toward the head, clearing visited bits
+// until it finds an unvisited entry to evict.
+type SIEVE[K comparable, V any] struct {
+	size  int
+	head  *sieveEntry[K, V]
+	tail  *sieveEntry[K, V]
+	hand  *sieveEntry[K, V]
+	items map[K]*sieveEntry[K, V]
+
+	onEvict EvictCallback[K, V]
+
+	weightTotal      uint64
+	weightLimit      uint64
+	weightCalculator WeightCalculator[V]
+}
+
+// NewSIEVE constructs a SIEVE cache of the given size.
+func NewSIEVE[K comparable, V any](size int, onEvict EvictCallback[K, V]) (*SIEVE[K, V], error) {
+	return NewSIEVEWithWeightLimit(size, 0, nil, onEvict)
+}
+
+// NewSIEVEWithWeightLimit constructs a fixed size SIEVE cache with the
+// weight limit and given eviction callback.
+func NewSIEVEWithWeightLimit[K comparable, V any](
+	size int,
+	weightLimit uint64,
+	weightCalculator WeightCalculator[V],
+	onEvict EvictCallback[K, V],
+) (*SIEVE[K, V], error) {
+	if size <= 0 {
+		return nil, errors.New("must provide a positive size")
+	}
+
+	c := &SIEVE[K, V]{
+		size:    size,
+		items:   make(map[K]*sieveEntry[K, V]),
+		onEvict: onEvict,
+
+		weightLimit:      weightLimit,
+		weightCalculator: weightCalculator,
+	}
+	return c, nil
+}
+
+// Purge is used to completely clear the cache.
+func (c *SIEVE[K, V]) Purge() {
+	for k, v := range c.items {
+		if c.onEvict != nil {
+			c.onEvict(k, v.value)
+		}
+		delete(c.items, k)
+	}
+	c.head, c.tail, c.hand = nil, nil, nil
+	c.weightTotal = 0
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *SIEVE[K, V]) Add(key K, value V) (evicted bool) {
+	// Check for existing item
+	if ent, ok := c.items[key]; ok {
+		ent.value = value
+		ent.visited = true
+
+		if c.weightCalculator != nil {
+			c.weightTotal -= ent.weight
+			ent.weight = c.weightCalculator(value)
+			c.weightTotal += ent.weight
+		}
+		return c.checkEvict() > 0
+	}
+
+	// Add new item at the head, unvisited
+	ent := &sieveEntry[K, V]{key: key, value: value}
+	c.linkFront(ent)
+	c.items[key] = ent
+
+	if c.weightCalculator != nil {
+		ent.weight = c.weightCalculator(value)
+		c.weightTotal += ent.weight
+	}
+	return c.checkEvict() > 0
+}
+
+// Get looks up a key's value from the cache. It marks the entry visited
+// but does not otherwise reorder the list.
+func (c *SIEVE[K, V]) Get(key K) (value V, ok bool) {
+	if ent, ok := c.items[key]; ok {
+		ent.visited = true
+		return ent.value, true
+	}
+	return
+}
+
+// Contains checks if a key is in the cache, without updating the
+// visited bit or deleting it for being stale.
+func (c *SIEVE[K, V]) Contains(key K) (ok bool) {
+	_, ok = c.items[key]
+	return ok
+}
+
+// Peek returns the key value (or undefined if not found) without updating
+// the visited bit of the key.
+func (c *SIEVE[K, V]) Peek(key K) (value V, ok bool) {
+	var ent *sieveEntry[K, V]
+	if ent, ok = c.items[key]; ok {
+		return ent.value, true
+	}
+	return
+}
+
+// Remove removes the provided key from the cache, returning if the
+// key was contained.
+func (c *SIEVE[K, V]) Remove(key K) (present bool) {
+	if ent, ok := c.items[key]; ok {
+		c.removeElement(ent)
+		return true
+	}
+	return false
+}
+
+// RemoveOldest removes the entry at the tail of the list.
+func (c *SIEVE[K, V]) RemoveOldest() (key K, value V, ok bool) {
+	if c.tail != nil {
+		e := c.tail
+		c.removeElement(e)
+		return e.key, e.value, true
+	}
+	return
+}
+
+// GetOldest returns the entry at the tail of the list.
+func (c *SIEVE[K, V]) GetOldest() (key K, value V, ok bool) {
+	if c.tail != nil {
+		return c.tail.key, c.tail.value, true
+	}
+	return
+}
+
+// Keys returns a slice of the keys in the cache, from oldest to newest.
+func (c *SIEVE[K, V]) Keys() []K {
+	keys := make([]K, 0, len(c.items))
+	for e := c.tail; e != nil; e = e.prev {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache.
+func (c *SIEVE[K, V]) Len() int {
+	return len(c.items)
+}
+
+// WeightTotal returns the sum of the weight of all the entries in the cache.
+func (c *SIEVE[K, V]) WeightTotal() uint64 {
+	return c.weightTotal
+}
+
+// Resize changes the cache size.
+func (c *SIEVE[K, V]) Resize(size int) (evicted int) {
+	c.size = size
+	return c.checkEvict()
+}
+
+// ResetWeightLimit changes the weight limit.
+func (c *SIEVE[K, V]) ResetWeightLimit(weightLimit uint64) (evicted int) {
+	c.weightLimit = weightLimit
+	return c.checkEvict()
+}
+
+// linkFront inserts e at the head of the list.
+func (c *SIEVE[K, V]) linkFront(e *sieveEntry[K, V]) {
+	e.prev = nil
+	e.next = c.head
+	if c.head != nil {
+		c.head.prev = e
+	}
+	c.head = e
+	if c.tail == nil {
+		c.tail = e
+	}
+}
+
+// unlink removes e from the list, fixing up head/tail and, if the hand
+// pointed at e, moving it to e's predecessor.
+func (c *SIEVE[K, V]) unlink(e *sieveEntry[K, V]) {
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else {
+		c.head = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else {
+		c.tail = e.prev
+	}
+	if c.hand == e {
+		c.hand = e.prev
+	}
+	e.prev, e.next = nil, nil
+}
+
+// findVictim walks the hand from its current position toward the head,
+// clearing visited bits, until it finds an entry to evict. A nil hand
+// (either never set, or having fallen off the head) restarts at the
+// tail.
+func (c *SIEVE[K, V]) findVictim() *sieveEntry[K, V] {
+	if c.tail == nil {
+		return nil
+	}
+	h := c.hand
+	if h == nil {
+		h = c.tail
+	}
+	for h.visited {
+		h.visited = false
+		if h.prev != nil {
+			h = h.prev
+		} else {
+			h = c.tail
+		}
+	}
+	return h
+}
+
+// checkEvict removes entries, via the SIEVE hand, until size and
+// weightLimit are both satisfied.
+func (c *SIEVE[K, V]) checkEvict() int {
+	var evicted int
+	for len(c.items) > c.size || c.weightTotal > c.weightLimit {
+		victim := c.findVictim()
+		if victim == nil {
+			break
+		}
+		// Leave the hand at the victim's predecessor, wrapping to the
+		// tail once it falls off the head.
+		c.hand = victim.prev
+		c.removeElement(victim)
+		if c.hand == nil {
+			c.hand = c.tail
+		}
+		evicted++
+	}
+	return evicted
+}
+
+// removeElement is used to remove a given list element from the cache
+func (c *SIEVE[K, V]) removeElement(e *sieveEntry[K, V]) {
+	c.unlink(e)
+	delete(c.items, e.key)
+	c.weightTotal -= e.weight
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+}
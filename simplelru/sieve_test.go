@@ -0,0 +1,54 @@
+package simplelru
+
+import "testing"
+
+// TestSIEVEEvictsUnvisitedBeforeVisited checks the core SIEVE invariant:
+// a visited entry survives one sweep of the hand (its bit is just
+// cleared), while the oldest unvisited entry is evicted first.
+func TestSIEVEEvictsUnvisitedBeforeVisited(t *testing.T) {
+	var evicted []int
+	c, err := NewSIEVE[int, int](2, func(k, v int) { evicted = append(evicted, k) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Add(1, 1)
+	c.Add(2, 2)
+	// Mark 1 visited so the hand should skip over it and take 2 instead.
+	if _, ok := c.Get(1); !ok {
+		t.Fatal("expected key 1 to be present")
+	}
+
+	c.Add(3, 3)
+
+	if len(evicted) != 1 || evicted[0] != 2 {
+		t.Fatalf("expected key 2 to be evicted first, got %v", evicted)
+	}
+	if !c.Contains(1) || !c.Contains(3) {
+		t.Fatalf("expected keys 1 and 3 resident, got keys=%v", c.Keys())
+	}
+}
+
+// TestSIEVEWeightLimit checks that a weight-limited SIEVE evicts down to
+// the configured limit as soon as it's exceeded.
+func TestSIEVEWeightLimit(t *testing.T) {
+	weightOf := func(v string) uint64 { return uint64(len(v)) }
+	c, err := NewSIEVEWithWeightLimit[string, string](10, 6, weightOf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Add("a", "abc")
+	c.Add("b", "abc")
+	if c.WeightTotal() != 6 {
+		t.Fatalf("want weight 6, got %d", c.WeightTotal())
+	}
+
+	c.Add("c", "abc")
+	if c.WeightTotal() > 6 {
+		t.Fatalf("want weight <= 6 after eviction, got %d", c.WeightTotal())
+	}
+	if c.Len() != 2 {
+		t.Fatalf("want 2 resident entries, got %d", c.Len())
+	}
+}
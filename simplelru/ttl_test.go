@@ -0,0 +1,62 @@
+package simplelru
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLRULazyExpiry checks that an expired entry is treated as absent by
+// Get, and that the lookup that discovers the expiry
+// reclaims the entry (fires onEvict with ReasonExpired) rather than
+// leaving it around for a later active sweep.
+func TestLRULazyExpiry(t *testing.T) {
+	var reason EvictReason
+	var calls int
+	c, err := newLRU[string, int](10, 0, nil, time.Millisecond, func(k string, v int, r EvictReason) {
+		calls++
+		reason = r
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Add("a", 1)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected expired entry to be absent from Get")
+	}
+	if calls != 1 || reason != ReasonExpired {
+		t.Fatalf("want 1 call with ReasonExpired, got %d calls reason=%v", calls, reason)
+	}
+	if c.Len() != 0 {
+		t.Fatalf("want 0 entries after lazy expiry, got %d", c.Len())
+	}
+}
+
+// TestLRUActiveExpiry checks that DeleteExpired reclaims every entry
+// whose TTL has elapsed without needing a Get/Contains/Peek to touch it.
+func TestLRUActiveExpiry(t *testing.T) {
+	c, err := NewLRUWithTTL[int, int](10, time.Millisecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		c.Add(i, i)
+	}
+	c.AddWithTTL(100, 100, 0) // TTL overridden to 0: never expires
+
+	time.Sleep(5 * time.Millisecond)
+
+	removed := c.DeleteExpired()
+	if removed != 3 {
+		t.Fatalf("want 3 entries reclaimed, got %d", removed)
+	}
+	if c.Len() != 1 {
+		t.Fatalf("want 1 entry remaining, got %d", c.Len())
+	}
+	if v, ok := c.Get(100); !ok || v != 100 {
+		t.Fatalf("want the non-expiring entry to survive, got %d ok=%v", v, ok)
+	}
+}